@@ -0,0 +1,105 @@
+package util
+
+import (
+	"net/url"
+	"reflect"
+	"strconv"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/pkg/errors"
+)
+
+// ToParams converts a an interface to url.Values by reflecting over the
+// struct's fields, using each field's name as the query key. Of the
+// generated ToParams methods under pkg/bindings/*, only
+// EventsOptions.ToParams calls it; the others carry their own copy of
+// the reflection switch and do not get the kinds handled here. Pointer,
+// map, slice and interface fields are skipped when nil. Bool, string,
+// int, int64, uint, uint64 and time.Time (as Unix seconds) values are
+// encoded, as are slices of string, int, int64, uint or uint64 (one value
+// per element) and map[string]string or map[string][]string (as JSON).
+// Any other slice or map type is an error; a field of any other kind is
+// left out.
+func ToParams(o interface{}) (url.Values, error) {
+	params := url.Values{}
+	if o == nil {
+		return params, nil
+	}
+	json := jsoniter.ConfigCompatibleWithStandardLibrary
+	s := reflect.ValueOf(o)
+	if reflect.Ptr == s.Kind() {
+		if s.IsNil() {
+			return params, nil
+		}
+		s = s.Elem()
+	}
+	sType := s.Type()
+	for i := 0; i < s.NumField(); i++ {
+		fieldName := sType.Field(i).Name
+		f := s.Field(i)
+		switch f.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+			if f.IsNil() {
+				continue
+			}
+		}
+		if reflect.Ptr == f.Kind() {
+			f = f.Elem()
+		}
+
+		if t, ok := f.Interface().(time.Time); ok {
+			params.Set(fieldName, strconv.FormatInt(t.Unix(), 10))
+			continue
+		}
+
+		switch f.Kind() {
+		case reflect.Bool:
+			params.Set(fieldName, strconv.FormatBool(f.Bool()))
+		case reflect.String:
+			params.Set(fieldName, f.String())
+		case reflect.Int, reflect.Int64:
+			// f.Int() is always an int64
+			params.Set(fieldName, strconv.FormatInt(f.Int(), 10))
+		case reflect.Uint, reflect.Uint64:
+			// f.Uint() is always an uint64
+			params.Set(fieldName, strconv.FormatUint(f.Uint(), 10))
+		case reflect.Slice:
+			typ := reflect.TypeOf(f.Interface()).Elem()
+			switch typ.Kind() {
+			case reflect.String:
+				for j := 0; j < f.Len(); j++ {
+					params.Add(fieldName, f.Index(j).String())
+				}
+			case reflect.Int, reflect.Int64:
+				for j := 0; j < f.Len(); j++ {
+					params.Add(fieldName, strconv.FormatInt(f.Index(j).Int(), 10))
+				}
+			case reflect.Uint, reflect.Uint64:
+				for j := 0; j < f.Len(); j++ {
+					params.Add(fieldName, strconv.FormatUint(f.Index(j).Uint(), 10))
+				}
+			default:
+				return nil, errors.Errorf("unknown slice type %s for field %s", typ.Kind().String(), fieldName)
+			}
+		case reflect.Map:
+			switch m := f.Interface().(type) {
+			case map[string][]string:
+				s, err := json.MarshalToString(m)
+				if err != nil {
+					return nil, err
+				}
+				params.Set(fieldName, s)
+			case map[string]string:
+				s, err := json.MarshalToString(m)
+				if err != nil {
+					return nil, err
+				}
+				params.Set(fieldName, s)
+			default:
+				return nil, errors.Errorf("unknown map type for field %s", fieldName)
+			}
+		}
+	}
+	return params, nil
+}
@@ -0,0 +1,81 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testOptions struct {
+	Flag      *bool
+	Name      *string
+	Count     *int64
+	Since     *time.Time
+	Codes     []int64
+	Labels    map[string]string
+	Filters   map[string][]string
+	Untouched *string
+}
+
+func TestToParamsNil(t *testing.T) {
+	params, err := ToParams(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, params)
+}
+
+func TestToParamsSkipsUnsetFields(t *testing.T) {
+	o := &testOptions{}
+	params, err := ToParams(o)
+	assert.NoError(t, err)
+	assert.Empty(t, params)
+}
+
+func TestToParamsBoolStringInt(t *testing.T) {
+	flag := true
+	name := "foo"
+	count := int64(42)
+	o := &testOptions{Flag: &flag, Name: &name, Count: &count}
+
+	params, err := ToParams(o)
+	assert.NoError(t, err)
+	assert.Equal(t, "true", params.Get("Flag"))
+	assert.Equal(t, "foo", params.Get("Name"))
+	assert.Equal(t, "42", params.Get("Count"))
+}
+
+func TestToParamsTime(t *testing.T) {
+	since := time.Date(2021, time.January, 2, 3, 4, 5, 0, time.UTC)
+	o := &testOptions{Since: &since}
+
+	params, err := ToParams(o)
+	assert.NoError(t, err)
+	assert.Equal(t, "1609556645", params.Get("Since"))
+}
+
+func TestToParamsIntSlice(t *testing.T) {
+	codes := []int64{0, 1, 137}
+	o := &testOptions{Codes: codes}
+
+	params, err := ToParams(o)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"0", "1", "137"}, params["Codes"])
+}
+
+func TestToParamsStringMap(t *testing.T) {
+	labels := map[string]string{"com.example": "value"}
+	o := &testOptions{Labels: labels}
+
+	params, err := ToParams(o)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"com.example":"value"}`, params.Get("Labels"))
+}
+
+func TestToParamsStringSliceMap(t *testing.T) {
+	filters := map[string][]string{"status": {"running", "paused"}}
+	o := &testOptions{Filters: filters}
+
+	params, err := ToParams(o)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":["running","paused"]}`, params.Get("Filters"))
+}
@@ -0,0 +1,183 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+)
+
+const defaultMaxBackoff = 30 * time.Second
+
+// EventHandler is called once per event received from the /events
+// endpoint. Returning an error stops the stream and is propagated to the
+// caller of Stream or StreamCh.
+type EventHandler func(entities.Event) error
+
+// handlerError wraps an error returned by an EventHandler so Stream can
+// tell it apart from a transport-level failure (dropped connection,
+// decode error) and never reconnects past it, regardless of Reconnect.
+type handlerError struct {
+	err error
+}
+
+func (h *handlerError) Error() string {
+	return h.err.Error()
+}
+
+func (h *handlerError) Unwrap() error {
+	return h.err
+}
+
+// Stream opens the /events endpoint and invokes handler for every event
+// decoded from the response. It honors ctx cancellation. When
+// options.Stream is true and the underlying connection is dropped,
+// Stream automatically reconnects if options.Reconnect is true, resuming
+// from the last event it saw using exponential backoff between attempts
+// capped at options.MaxBackoff (defaultMaxBackoff when unset). An error
+// returned by handler always stops the stream immediately and is
+// returned to the caller unwrapped, whether or not Reconnect is set;
+// only transport-level failures are eligible for reconnect.
+func Stream(ctx context.Context, options *EventsOptions, handler EventHandler) error {
+	if options == nil {
+		options = new(EventsOptions)
+	}
+	maxBackoff := options.GetMaxBackoff()
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	backoff := 500 * time.Millisecond
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	for {
+		lastSeen, err := streamOnce(ctx, options, handler)
+		var hErr *handlerError
+		if errors.As(err, &hErr) {
+			// handler asked to stop; never reconnect past that,
+			// Reconnect notwithstanding.
+			return hErr.err
+		}
+		if err == nil && !options.GetStream() {
+			// A non-streaming request (Stream=false) ends at EOF by
+			// design; there is nothing to reconnect to.
+			return nil
+		}
+		if err == nil {
+			// The connection was closed cleanly while still streaming,
+			// which looks identical to a dropped connection from here.
+			err = io.ErrUnexpectedEOF
+		}
+		switch {
+		case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+			return err
+		case !options.GetReconnect():
+			return err
+		}
+
+		logrus.Infof("events stream disconnected, reconnecting: %v", err)
+		if lastSeen != "" {
+			options = options.WithSince(lastSeen)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(jitter(backoff)):
+		}
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// StreamCh is a channel-based variant of Stream. It returns a channel of
+// decoded events and a channel that receives at most one error once the
+// stream ends, either because ctx was canceled, the handler-less read
+// reached a non-recoverable error, or the server closed the stream and
+// Reconnect was not requested. Both channels are closed when the stream
+// ends.
+func StreamCh(ctx context.Context, options *EventsOptions) (<-chan entities.Event, <-chan error) {
+	eventCh := make(chan entities.Event)
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(eventCh)
+		defer close(errCh)
+		err := Stream(ctx, options, func(e entities.Event) error {
+			select {
+			case eventCh <- e:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		if err != nil {
+			errCh <- err
+		}
+	}()
+
+	return eventCh, errCh
+}
+
+// streamOnce performs a single, non-reconnecting pass over the /events
+// endpoint. It returns the timestamp of the last successfully handled
+// event (suitable for a subsequent Since) alongside any error.
+func streamOnce(ctx context.Context, options *EventsOptions, handler EventHandler) (string, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	params, err := options.ToParams()
+	if err != nil {
+		return "", err
+	}
+	// Reconnect/MaxBackoff are client-side only and have no meaning to
+	// the server.
+	params.Del("Reconnect")
+	params.Del("MaxBackoff")
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/events", params, nil)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return "", response.Process(nil)
+	}
+
+	var lastSeen string
+	dec := json.NewDecoder(response.Body)
+	for {
+		var e entities.Event
+		if err := dec.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				return lastSeen, nil
+			}
+			return lastSeen, err
+		}
+		if err := handler(e); err != nil {
+			return lastSeen, &handlerError{err: err}
+		}
+		// Resume one second past this event on reconnect so it isn't
+		// redelivered; Since has only second resolution.
+		lastSeen = strconv.FormatInt(e.Time.Add(time.Second).Unix(), 10)
+	}
+}
+
+// jitter adds up to 20% random jitter to d to avoid reconnect storms
+// against a shared server.
+func jitter(d time.Duration) time.Duration {
+	return d + time.Duration(rand.Int63n(int64(d)/5+1))
+}
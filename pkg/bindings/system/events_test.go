@@ -0,0 +1,126 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v4/pkg/bindings"
+	"github.com/containers/podman/v4/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConnection starts an httptest server driven by handler and
+// returns a context bound to a bindings connection pointed at it.
+func newTestConnection(t *testing.T, handler http.HandlerFunc) context.Context {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	ctx, err := bindings.NewConnection(context.Background(), server.URL)
+	require.NoError(t, err)
+	return ctx
+}
+
+func writeEvents(w http.ResponseWriter, events []entities.Event) {
+	enc := json.NewEncoder(w)
+	for _, e := range events {
+		_ = enc.Encode(e)
+	}
+}
+
+func TestStreamHandlerErrorStopsEvenWithReconnect(t *testing.T) {
+	calls := 0
+	ctx := newTestConnection(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeEvents(w, []entities.Event{{Time: time.Unix(1, 0)}, {Time: time.Unix(2, 0)}})
+	})
+
+	wantErr := errors.New("stop here")
+	seen := 0
+	options := new(EventsOptions).WithStream(true).WithReconnect(true)
+	err := Stream(ctx, options, func(e entities.Event) error {
+		seen++
+		return wantErr
+	})
+
+	assert.ErrorIs(t, err, wantErr)
+	assert.Equal(t, 1, seen, "the handler should only be invoked once before the stream stops")
+	assert.Equal(t, 1, calls, "a handler error must not trigger a reconnect")
+}
+
+func TestStreamReconnectsOnDisconnectAndResumesSince(t *testing.T) {
+	var sinceSeen []string
+	calls := 0
+	ctx := newTestConnection(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		sinceSeen = append(sinceSeen, r.URL.Query().Get("Since"))
+		if calls == 1 {
+			// Simulate a mid-stream disconnect: the body just ends.
+			writeEvents(w, []entities.Event{{Time: time.Unix(100, 0)}})
+			return
+		}
+		writeEvents(w, []entities.Event{{Time: time.Unix(200, 0)}})
+	})
+
+	stop := errors.New("stop after two events")
+	seen := 0
+	options := new(EventsOptions).WithStream(true).WithReconnect(true).WithMaxBackoff(time.Millisecond)
+	err := Stream(ctx, options, func(e entities.Event) error {
+		seen++
+		if seen == 2 {
+			return stop
+		}
+		return nil
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, calls, "the dropped connection should trigger exactly one reconnect")
+	require.Len(t, sinceSeen, 2)
+	assert.Empty(t, sinceSeen[0], "the first request should not carry a Since")
+	assert.Equal(t, "101", sinceSeen[1], "the reconnect should resume one second past the last event seen")
+}
+
+func TestStreamErrorStatusIsNotDecodedAsAnEvent(t *testing.T) {
+	calls := 0
+	ctx := newTestConnection(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_ = json.NewEncoder(w).Encode(map[string]string{"cause": "boom", "message": "internal error"})
+	})
+
+	seen := 0
+	options := new(EventsOptions).WithStream(false)
+	err := Stream(ctx, options, func(e entities.Event) error {
+		seen++
+		return nil
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Zero(t, seen, "an error response body must never be decoded as an event")
+}
+
+func TestStreamNoReconnectWhenNotStreaming(t *testing.T) {
+	calls := 0
+	ctx := newTestConnection(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeEvents(w, []entities.Event{{Time: time.Unix(1, 0)}})
+	})
+
+	seen := 0
+	options := new(EventsOptions).WithStream(false).WithReconnect(true)
+	err := Stream(ctx, options, func(e entities.Event) error {
+		seen++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls, "a one-shot (Stream=false) request must not reconnect on EOF")
+	assert.Equal(t, 1, seen)
+}
@@ -0,0 +1,25 @@
+package system
+
+import "time"
+
+// EventsOptions are optional options for monitoring events
+//
+//go:generate go run ../generator/generator.go EventsOptions
+type EventsOptions struct {
+	// Filters to apply to the event stream, e.g. {"event": {"start"}}.
+	Filters map[string][]string
+	// Since events from this point in time.
+	Since *string
+	// Stream the events; if false only events since Since are returned.
+	Stream *bool
+	// Until events up to this point in time.
+	Until *string
+	// Reconnect automatically resumes the stream, picking up from the
+	// last-seen event's timestamp, if the connection drops while
+	// Stream is set. Only consulted by Stream and StreamCh.
+	Reconnect *bool
+	// MaxBackoff caps the exponential backoff applied between
+	// reconnect attempts. Defaults to 30s when unset. Only consulted
+	// by Stream and StreamCh.
+	MaxBackoff *time.Duration
+}
@@ -3,10 +3,9 @@ package system
 import (
 	"net/url"
 	"reflect"
-	"strconv"
+	"time"
 
-	jsoniter "github.com/json-iterator/go"
-	"github.com/pkg/errors"
+	"github.com/containers/podman/v4/pkg/bindings/internal/util"
 )
 
 /*
@@ -24,67 +23,10 @@ func (o *EventsOptions) Changed(fieldName string) bool {
 
 // ToParams
 func (o *EventsOptions) ToParams() (url.Values, error) {
-	params := url.Values{}
 	if o == nil {
-		return params, nil
+		return url.Values{}, nil
 	}
-	json := jsoniter.ConfigCompatibleWithStandardLibrary
-	s := reflect.ValueOf(o)
-	if reflect.Ptr == s.Kind() {
-		s = s.Elem()
-	}
-	sType := s.Type()
-	for i := 0; i < s.NumField(); i++ {
-		fieldName := sType.Field(i).Name
-		if !o.Changed(fieldName) {
-			continue
-		}
-		f := s.Field(i)
-		if reflect.Ptr == f.Kind() {
-			f = f.Elem()
-		}
-		switch f.Kind() {
-		case reflect.Bool:
-			params.Set(fieldName, strconv.FormatBool(f.Bool()))
-		case reflect.String:
-			params.Set(fieldName, f.String())
-		case reflect.Int, reflect.Int64:
-			// f.Int() is always an int64
-			params.Set(fieldName, strconv.FormatInt(f.Int(), 10))
-		case reflect.Uint, reflect.Uint64:
-			// f.Uint() is always an uint64
-			params.Set(fieldName, strconv.FormatUint(f.Uint(), 10))
-		case reflect.Slice:
-			typ := reflect.TypeOf(f.Interface()).Elem()
-			slice := reflect.MakeSlice(reflect.SliceOf(typ), f.Len(), f.Cap())
-			switch typ.Kind() {
-			case reflect.String:
-				s, ok := slice.Interface().([]string)
-				if !ok {
-					return nil, errors.New("failed to convert to string slice")
-				}
-				for _, val := range s {
-					params.Add(fieldName, val)
-				}
-			default:
-				return nil, errors.Errorf("unknown slice type %s", f.Kind().String())
-			}
-		case reflect.Map:
-			lowerCaseKeys := make(map[string][]string)
-			iter := f.MapRange()
-			for iter.Next() {
-				lowerCaseKeys[iter.Key().Interface().(string)] = iter.Value().Interface().([]string)
-
-			}
-			s, err := json.MarshalToString(lowerCaseKeys)
-			if err != nil {
-				return nil, err
-			}
-
-			params.Set(fieldName, s)
-		}
-	}
-	return params, nil
+	return util.ToParams(o)
 }
 
 // WithFilters
@@ -150,3 +92,35 @@ func (o *EventsOptions) GetUntil() string {
 	}
 	return *o.Until
 }
+
+// WithReconnect
+func (o *EventsOptions) WithReconnect(value bool) *EventsOptions {
+	v := &value
+	o.Reconnect = v
+	return o
+}
+
+// GetReconnect
+func (o *EventsOptions) GetReconnect() bool {
+	var reconnect bool
+	if o.Reconnect == nil {
+		return reconnect
+	}
+	return *o.Reconnect
+}
+
+// WithMaxBackoff
+func (o *EventsOptions) WithMaxBackoff(value time.Duration) *EventsOptions {
+	v := &value
+	o.MaxBackoff = v
+	return o
+}
+
+// GetMaxBackoff
+func (o *EventsOptions) GetMaxBackoff() time.Duration {
+	var maxBackoff time.Duration
+	if o.MaxBackoff == nil {
+		return maxBackoff
+	}
+	return *o.MaxBackoff
+}
@@ -0,0 +1,57 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventsOptionsToParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		options func() *EventsOptions
+		key     string
+		want    string
+	}{
+		{
+			name:    "since",
+			options: func() *EventsOptions { return new(EventsOptions).WithSince("1136214245") },
+			key:     "Since",
+			want:    "1136214245",
+		},
+		{
+			name:    "stream",
+			options: func() *EventsOptions { return new(EventsOptions).WithStream(true) },
+			key:     "Stream",
+			want:    "true",
+		},
+		{
+			name: "filters",
+			options: func() *EventsOptions {
+				return new(EventsOptions).WithFilters(map[string][]string{"event": {"start"}})
+			},
+			key:  "Filters",
+			want: `{"event":["start"]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.options().ToParams()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, params.Get(tt.key))
+		})
+	}
+}
+
+func TestEventsOptionsToParamsNilOptions(t *testing.T) {
+	var o *EventsOptions
+	params, err := o.ToParams()
+	assert.NoError(t, err)
+	assert.Empty(t, params)
+}
+
+func TestEventsOptionsToParamsUnsetFieldsOmitted(t *testing.T) {
+	params, err := new(EventsOptions).ToParams()
+	assert.NoError(t, err)
+	assert.Empty(t, params)
+}
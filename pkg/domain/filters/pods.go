@@ -6,6 +6,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/common/pkg/filters"
 	cutil "github.com/containers/common/pkg/util"
@@ -14,9 +15,112 @@ import (
 	"github.com/containers/podman/v4/pkg/util"
 )
 
+// healthQuery is a single parsed "health" filter value, e.g.
+// "any:healthy" or "all:unhealthy".
+type healthQuery struct {
+	all   bool
+	state string
+}
+
+// parsePodHealthQueries validates and parses the raw "health" filter
+// values ("[any|all:]<state>", defaulting to any) up front so
+// GeneratePodFilterFunc can reject unknown modes/states before
+// returning a filter func.
+func parsePodHealthQueries(filterValues []string) ([]healthQuery, error) {
+	validStates := []string{"healthy", "unhealthy", "starting", "none"}
+	queries := make([]healthQuery, 0, len(filterValues))
+	for _, filterValue := range filterValues {
+		mode, state := "any", filterValue
+		if before, after, found := strings.Cut(filterValue, ":"); found {
+			mode, state = before, after
+		}
+		if mode != "any" && mode != "all" {
+			return nil, fmt.Errorf("%s is not a valid health filter mode, must be any or all", mode)
+		}
+		if !cutil.StringInSlice(state, validStates) {
+			return nil, fmt.Errorf("%s is not a valid health state", state)
+		}
+		queries = append(queries, healthQuery{all: mode == "all", state: state})
+	}
+	return queries, nil
+}
+
+// matchPodHealth reports whether statuses, the healthcheck status of
+// each of a pod's containers, satisfies any of queries. A pod with no
+// containers never matches.
+func matchPodHealth(queries []healthQuery, statuses []string) bool {
+	if len(statuses) == 0 {
+		return false
+	}
+	for _, q := range queries {
+		matched := q.all
+		for _, status := range statuses {
+			if q.all {
+				if status != q.state {
+					matched = false
+					break
+				}
+			} else if status == q.state {
+				matched = true
+				break
+			}
+		}
+		if matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchCgroupParent reports whether parent matches any of
+// filterValues, each of which may be a literal string or a regex.
+func matchCgroupParent(parent string, filterValues []string) bool {
+	return util.StringMatchRegexSlice(parent, filterValues)
+}
+
+// podCreatedBefore reports whether created is before any of the times
+// parsed from filterValues.
+func podCreatedBefore(created time.Time, filterValues []string) bool {
+	for _, filterValue := range filterValues {
+		before, err := util.ParseInputTime(filterValue, true)
+		if err != nil {
+			continue
+		}
+		if created.Before(before) {
+			return true
+		}
+	}
+	return false
+}
+
+// podCreatedAfter reports whether created is after any of the times
+// parsed from filterValues.
+func podCreatedAfter(created time.Time, filterValues []string) bool {
+	for _, filterValue := range filterValues {
+		after, err := util.ParseInputTime(filterValue, true)
+		if err != nil {
+			continue
+		}
+		if created.After(after) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLabelNot reports whether labels does NOT match filterValues,
+// i.e. the inverse of the "label" filter.
+func matchLabelNot(filterValues []string, labels map[string]string) bool {
+	return !filters.MatchLabelFilters(filterValues, labels)
+}
+
 // GeneratePodFilterFunc takes a filter and filtervalue (key, value)
 // and generates a libpod function that can be used to filter
-// pods
+// pods. Supported filters beyond id/name/status/label/until/network and
+// the ctr-* family include: health (any|all of the pod's containers match
+// a healthcheck state, e.g. "health=any:healthy"), cgroup-parent
+// (string or regex match), created-before / created-after (compare
+// against the pod's creation time), and label! (negated label match).
 func GeneratePodFilterFunc(filter string, filterValues []string, r *libpod.Runtime) (
 	func(pod *libpod.Pod) bool, error) {
 	switch filter {
@@ -147,6 +251,42 @@ func GeneratePodFilterFunc(filter string, filterValues []string, r *libpod.Runti
 			labels := p.Labels()
 			return filters.MatchLabelFilters(filterValues, labels)
 		}, nil
+	case "label!":
+		return func(p *libpod.Pod) bool {
+			return matchLabelNot(filterValues, p.Labels())
+		}, nil
+	case "health":
+		queries, err := parsePodHealthQueries(filterValues)
+		if err != nil {
+			return nil, err
+		}
+		return func(p *libpod.Pod) bool {
+			ctrs, err := p.AllContainers()
+			if err != nil {
+				return false
+			}
+			statuses := make([]string, 0, len(ctrs))
+			for _, ctr := range ctrs {
+				status, err := ctr.HealthCheckStatus()
+				if err != nil {
+					status = "none"
+				}
+				statuses = append(statuses, status)
+			}
+			return matchPodHealth(queries, statuses)
+		}, nil
+	case "cgroup-parent":
+		return func(p *libpod.Pod) bool {
+			return matchCgroupParent(p.CgroupParent(), filterValues)
+		}, nil
+	case "created-before":
+		return func(p *libpod.Pod) bool {
+			return podCreatedBefore(p.CreatedTime(), filterValues)
+		}, nil
+	case "created-after":
+		return func(p *libpod.Pod) bool {
+			return podCreatedAfter(p.CreatedTime(), filterValues)
+		}, nil
 	case "until":
 		return func(p *libpod.Pod) bool {
 			until, err := util.ComputeUntilTimestamp(filterValues)
@@ -0,0 +1,104 @@
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeneratePodFilterFuncHealthValidation(t *testing.T) {
+	tests := []struct {
+		name         string
+		filterValues []string
+		wantErr      bool
+	}{
+		{"bare state", []string{"healthy"}, false},
+		{"any state", []string{"any:healthy"}, false},
+		{"all state", []string{"all:unhealthy"}, false},
+		{"none state", []string{"none"}, false},
+		{"multi value", []string{"any:healthy", "all:starting"}, false},
+		{"unknown state", []string{"bogus"}, true},
+		{"unknown mode", []string{"sometimes:healthy"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := GeneratePodFilterFunc("health", tt.filterValues, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGeneratePodFilterFuncUnknownFilter(t *testing.T) {
+	_, err := GeneratePodFilterFunc("bogus-filter", []string{"x"}, nil)
+	assert.Error(t, err)
+}
+
+func TestMatchPodHealth(t *testing.T) {
+	anyHealthy, err := parsePodHealthQueries([]string{"any:healthy"})
+	require.NoError(t, err)
+	assert.True(t, matchPodHealth(anyHealthy, []string{"unhealthy", "healthy", "starting"}))
+	assert.False(t, matchPodHealth(anyHealthy, []string{"unhealthy", "starting"}))
+
+	allHealthy, err := parsePodHealthQueries([]string{"all:healthy"})
+	require.NoError(t, err)
+	assert.True(t, matchPodHealth(allHealthy, []string{"healthy", "healthy"}))
+	assert.False(t, matchPodHealth(allHealthy, []string{"healthy", "unhealthy"}))
+	assert.False(t, matchPodHealth(allHealthy, nil), "a pod with no containers should never match")
+
+	// Multiple filter values are OR'd together: a pod matches if any one
+	// of the parsed queries is satisfied.
+	multi, err := parsePodHealthQueries([]string{"all:unhealthy", "any:starting"})
+	require.NoError(t, err)
+	assert.True(t, matchPodHealth(multi, []string{"starting", "healthy"}))
+	assert.False(t, matchPodHealth(multi, []string{"healthy", "healthy"}))
+
+	// A bare state defaults to "any".
+	bare, err := parsePodHealthQueries([]string{"healthy"})
+	require.NoError(t, err)
+	assert.True(t, matchPodHealth(bare, []string{"unhealthy", "healthy"}))
+}
+
+func TestMatchCgroupParent(t *testing.T) {
+	assert.True(t, matchCgroupParent("/machine.slice/libpod-abc", []string{"machine.slice"}))
+	assert.False(t, matchCgroupParent("/user.slice/libpod-abc", []string{"machine.slice"}))
+
+	// Regex matching.
+	assert.True(t, matchCgroupParent("/machine.slice/libpod-abc", []string{"^/machine\\.slice/libpod-.*$"}))
+	assert.False(t, matchCgroupParent("/machine.slice/libpod-abc", []string{"^/user\\.slice/.*$"}))
+
+	// Multiple filter values are OR'd together.
+	assert.True(t, matchCgroupParent("/user.slice/libpod-abc", []string{"machine.slice", "user.slice"}))
+	assert.False(t, matchCgroupParent("/other.slice/libpod-abc", []string{"machine.slice", "user.slice"}))
+}
+
+func TestPodCreatedBeforeAfter(t *testing.T) {
+	created := time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, podCreatedBefore(created, []string{"2024-01-16T00:00:00Z"}))
+	assert.False(t, podCreatedBefore(created, []string{"2024-01-01T00:00:00Z"}))
+
+	assert.True(t, podCreatedAfter(created, []string{"2024-01-01T00:00:00Z"}))
+	assert.False(t, podCreatedAfter(created, []string{"2024-01-16T00:00:00Z"}))
+
+	// Multiple filter values are OR'd together.
+	assert.True(t, podCreatedBefore(created, []string{"2020-01-01T00:00:00Z", "2024-01-16T00:00:00Z"}))
+	assert.True(t, podCreatedAfter(created, []string{"2024-01-16T00:00:00Z", "2024-01-01T00:00:00Z"}))
+
+	// An unparsable value is skipped rather than matching.
+	assert.False(t, podCreatedBefore(created, []string{"not-a-time"}))
+	assert.False(t, podCreatedAfter(created, []string{"not-a-time"}))
+}
+
+func TestMatchLabelNot(t *testing.T) {
+	labels := map[string]string{"foo": "bar"}
+
+	assert.False(t, matchLabelNot([]string{"foo=bar"}, labels), "label! should invert a matching label filter")
+	assert.True(t, matchLabelNot([]string{"foo=baz"}, labels), "label! should match when the label filter does not")
+	assert.True(t, matchLabelNot([]string{"missing"}, labels), "label! should match when the key is absent")
+}